@@ -0,0 +1,129 @@
+package lgr
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Async wraps a writer with a bounded queue drained by a single
+// background goroutine, so Infof/Warnf callers never block on a slow
+// sink such as a disk or a network socket. Build one with AsyncWriter.
+type Async struct {
+	inner  io.Writer
+	queue  chan []byte
+	onDrop func(dropped uint64)
+
+	dropped uint64 // atomic
+	pending int64  // atomic: records queued but not yet written
+
+	closeOnce sync.Once
+	done      chan struct{}
+	stopped   chan struct{}
+}
+
+// AsyncWriter returns an Async ready to use as a lgr.Writer(...) argument.
+// capacity bounds how many pending records may queue before new writes
+// are dropped; onDrop, if non-nil, is called with the running drop total
+// every time a record is dropped.
+func AsyncWriter(inner io.Writer, capacity int, onDrop func(dropped uint64)) *Async {
+	a := &Async{
+		inner:   inner,
+		queue:   make(chan []byte, capacity),
+		onDrop:  onDrop,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	go a.drain()
+
+	return a
+}
+
+// Write copies b into the ring buffer and returns immediately without
+// touching inner. When the ring is full, b is dropped: the drop counter
+// is incremented and a synthetic "dropped=N" line is flushed to inner the
+// next time the consumer goroutine observes the counter changed.
+func (a *Async) Write(b []byte) (int, error) {
+	rec := make([]byte, len(b))
+	copy(rec, b)
+
+	select {
+	case a.queue <- rec:
+		atomic.AddInt64(&a.pending, 1)
+	default:
+		dropped := atomic.AddUint64(&a.dropped, 1)
+
+		if a.onDrop != nil {
+			a.onDrop(dropped)
+		}
+	}
+
+	return len(b), nil
+}
+
+func (a *Async) drain() {
+	defer close(a.stopped)
+
+	var lastDropped uint64
+
+	for {
+		select {
+		case rec := <-a.queue:
+			a.write(rec)
+		case <-a.done:
+			a.drainRemaining()
+
+			return
+		}
+
+		if dropped := atomic.LoadUint64(&a.dropped); dropped != lastDropped {
+			lastDropped = dropped
+			_, _ = fmt.Fprintf(a.inner, "dropped=%d\n", dropped)
+		}
+	}
+}
+
+func (a *Async) write(rec []byte) {
+	_, _ = a.inner.Write(rec)
+	atomic.AddInt64(&a.pending, -1)
+}
+
+func (a *Async) drainRemaining() {
+	for {
+		select {
+		case rec := <-a.queue:
+			a.write(rec)
+		default:
+			return
+		}
+	}
+}
+
+// Sync blocks until every record queued before the call has been written
+// to inner.
+func (a *Async) Sync() error {
+	for atomic.LoadInt64(&a.pending) > 0 {
+		runtime.Gosched()
+	}
+
+	return nil
+}
+
+// Close drains any queued records, stops the background goroutine and
+// closes inner if it implements io.Closer. It is safe to call once.
+func (a *Async) Close() error {
+	a.closeOnce.Do(func() {
+		close(a.done)
+	})
+
+	<-a.stopped
+
+	if closer, ok := a.inner.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package lgr_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/WLM1ke/poptimizer/data/pkg/lgr"
+)
+
+// slowWriter stalls every Write, standing in for a slow sink (disk,
+// network) so AsyncWriter's queue is guaranteed to fill up under a burst.
+type slowWriter struct{}
+
+func (slowWriter) Write(b []byte) (int, error) {
+	time.Sleep(10 * time.Millisecond)
+
+	return len(b), nil
+}
+
+// syncCloseRecorder wraps a bytes.Buffer to observe whether Sync/Close
+// were called, guarding concurrent access from the Async drain goroutine.
+type syncCloseRecorder struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	closed bool
+}
+
+func (r *syncCloseRecorder) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.buf.Write(b)
+}
+
+func (r *syncCloseRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+
+	return nil
+}
+
+func (r *syncCloseRecorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.buf.String()
+}
+
+func TestAsyncWriterDropsWhenFull(t *testing.T) {
+	var dropped uint64
+
+	async := lgr.AsyncWriter(slowWriter{}, 1, func(d uint64) {
+		dropped = d
+	})
+	defer async.Close()
+
+	for i := 0; i < 100; i++ {
+		_, _ = async.Write([]byte("line\n"))
+	}
+
+	if dropped == 0 {
+		t.Error("expected AsyncWriter to drop at least one record once its queue filled up")
+	}
+}
+
+func TestLoggerCloseClosesLevelWriters(t *testing.T) {
+	rec := &syncCloseRecorder{}
+	async := lgr.AsyncWriter(rec, 16, nil)
+
+	logger := lgr.WithOptions(
+		lgr.Name("test"),
+		lgr.Writer(&bytes.Buffer{}),
+		lgr.LevelWriter(lgr.Error, async),
+	)
+
+	logger.Errorf("boom\n")
+
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() = %v, want nil", err)
+	}
+
+	if !rec.closed {
+		t.Error("Logger.Close did not close the writer installed via LevelWriter")
+	}
+
+	if !strings.Contains(rec.String(), "boom") {
+		t.Errorf("Close returned before the queued record reached the writer: %q", rec.String())
+	}
+}
@@ -0,0 +1,24 @@
+package lgr
+
+import "context"
+
+type ctxKey struct{}
+
+// ToContext returns a copy of ctx carrying logger, retrievable later with
+// FromContext. This is the idiomatic way to thread a request-scoped
+// logger - typically one built with Logger.With to attach a request ID
+// or trace ID - through the data package's HTTP handlers and async
+// workers without passing it explicitly.
+func ToContext(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext returns the Logger stored in ctx by ToContext, or a
+// default Logger built with New if ctx carries none.
+func FromContext(ctx context.Context) *Logger {
+	if logger, ok := ctx.Value(ctxKey{}).(*Logger); ok {
+		return logger
+	}
+
+	return New()
+}
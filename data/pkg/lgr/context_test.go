@@ -0,0 +1,53 @@
+package lgr_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/WLM1ke/poptimizer/data/pkg/lgr"
+)
+
+func TestLoggerWithPreEncodesFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	base := lgr.WithOptions(lgr.Name("test"), lgr.Writer(&buf))
+	child := base.With(lgr.String("request_id", "abc123"))
+
+	child.Info("handled")
+	base.Info("unrelated")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+
+	if !strings.Contains(lines[0], "request_id=abc123") {
+		t.Errorf("child record missing request_id field: %q", lines[0])
+	}
+
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("With mutated the parent logger: %q", lines[1])
+	}
+}
+
+func TestToContextFromContextRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(&buf)).With(lgr.String("trace_id", "t-1"))
+
+	ctx := lgr.ToContext(context.Background(), logger)
+
+	lgr.FromContext(ctx).Info("from context")
+
+	if !strings.Contains(buf.String(), "trace_id=t-1") {
+		t.Errorf("logger retrieved via FromContext lost its With fields: %q", buf.String())
+	}
+}
+
+func TestFromContextWithoutLoggerReturnsDefault(t *testing.T) {
+	if logger := lgr.FromContext(context.Background()); logger == nil {
+		t.Fatal("FromContext on a context with no logger returned nil")
+	}
+}
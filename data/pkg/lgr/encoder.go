@@ -0,0 +1,238 @@
+package lgr
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Encoder renders one log record into buf. Implementations must not
+// retain buf past the call.
+type Encoder interface {
+	// encode renders one structured Info/Warn/... record, writing prev
+	// (a With fragment produced by encodeExtra) verbatim right after
+	// msg, followed by fields.
+	encode(buf *bytes.Buffer, level Level, name string, now time.Time, timeLayout string, msg string, prev []byte, fields []Field)
+	// encodef renders one printf-style Infof/Warnf/... record.
+	encodef(buf *bytes.Buffer, level Level, name string, now time.Time, timeLayout string, format string, args []interface{})
+	// encodeExtra appends fields to prev in this encoder's wire format,
+	// returning the fragment a child Logger built by With stores and
+	// replays on every later call via encode's prev parameter.
+	encodeExtra(prev []byte, fields []Field) []byte
+}
+
+var bufPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+func getBuf() *bytes.Buffer {
+	return bufPool.Get().(*bytes.Buffer)
+}
+
+func putBuf(buf *bytes.Buffer) {
+	buf.Reset()
+	bufPool.Put(buf)
+}
+
+// writeTimestamp appends now, formatted per layout, straight into buf
+// using a stack-local scratch array - AppendFormat, unlike Format, never
+// allocates a throwaway string just to copy it into buf right after.
+func writeTimestamp(buf *bytes.Buffer, now time.Time, timeLayout string) {
+	var tmp [64]byte
+	buf.Write(now.AppendFormat(tmp[:0], timeLayout))
+}
+
+// consoleEncoder renders "ts LEVEL name: msg key=val key=val" lines.
+type consoleEncoder struct{}
+
+func (consoleEncoder) encode(buf *bytes.Buffer, level Level, name string, now time.Time, timeLayout string, msg string, prev []byte, fields []Field) {
+	writeTimestamp(buf, now, timeLayout)
+	buf.WriteByte(' ')
+	buf.WriteString(level.String())
+	buf.WriteByte(' ')
+	buf.WriteString(name)
+	buf.WriteString(": ")
+	buf.WriteString(msg)
+	buf.Write(prev)
+
+	for _, f := range fields {
+		buf.WriteByte(' ')
+		buf.WriteString(f.Key)
+		buf.WriteByte('=')
+		writeFieldValue(buf, f)
+	}
+
+	buf.WriteByte('\n')
+}
+
+func (consoleEncoder) encodef(buf *bytes.Buffer, level Level, name string, now time.Time, timeLayout string, format string, args []interface{}) {
+	writeTimestamp(buf, now, timeLayout)
+	buf.WriteByte(' ')
+	buf.WriteString(level.String())
+	buf.WriteByte(' ')
+	buf.WriteString(name)
+	buf.WriteString(": ")
+	appendf(buf, format, args...)
+}
+
+func (consoleEncoder) encodeExtra(prev []byte, fields []Field) []byte {
+	buf := bytes.NewBuffer(append([]byte(nil), prev...))
+
+	for _, f := range fields {
+		buf.WriteByte(' ')
+		buf.WriteString(f.Key)
+		buf.WriteByte('=')
+		writeFieldValue(buf, f)
+	}
+
+	return buf.Bytes()
+}
+
+// jsonEncoder renders one JSON object per record, each field becoming a
+// top level key so downstream ingestion can filter on it directly.
+type jsonEncoder struct{}
+
+func (jsonEncoder) encode(buf *bytes.Buffer, level Level, name string, now time.Time, timeLayout string, msg string, prev []byte, fields []Field) {
+	buf.WriteByte('{')
+
+	buf.WriteString(`"ts":"`)
+	writeTimestamp(buf, now, timeLayout)
+	buf.WriteString(`","level":"`)
+	buf.WriteString(level.String())
+	buf.WriteString(`","name":"`)
+	writeJSONEscaped(buf, name)
+	buf.WriteString(`","msg":"`)
+	writeJSONEscaped(buf, msg)
+	buf.WriteByte('"')
+	buf.Write(prev)
+
+	for _, f := range fields {
+		buf.WriteByte(',')
+		buf.WriteByte('"')
+		writeJSONEscaped(buf, f.Key)
+		buf.WriteString(`":`)
+		writeFieldJSON(buf, f)
+	}
+
+	buf.WriteString("}\n")
+}
+
+func (jsonEncoder) encodef(buf *bytes.Buffer, level Level, name string, now time.Time, timeLayout string, format string, args []interface{}) {
+	msgBuf := getBuf()
+	appendf(msgBuf, format, args...)
+
+	buf.WriteByte('{')
+
+	buf.WriteString(`"ts":"`)
+	writeTimestamp(buf, now, timeLayout)
+	buf.WriteString(`","level":"`)
+	buf.WriteString(level.String())
+	buf.WriteString(`","name":"`)
+	writeJSONEscaped(buf, name)
+	buf.WriteString(`","msg":"`)
+	writeJSONEscapedBytes(buf, msgBuf.Bytes())
+	buf.WriteByte('"')
+	buf.WriteString("}\n")
+
+	putBuf(msgBuf)
+}
+
+func (jsonEncoder) encodeExtra(prev []byte, fields []Field) []byte {
+	buf := bytes.NewBuffer(append([]byte(nil), prev...))
+
+	for _, f := range fields {
+		buf.WriteByte(',')
+		buf.WriteByte('"')
+		writeJSONEscaped(buf, f.Key)
+		buf.WriteString(`":`)
+		writeFieldJSON(buf, f)
+	}
+
+	return buf.Bytes()
+}
+
+func writeFieldValue(buf *bytes.Buffer, f Field) {
+	switch f.ftype {
+	case stringType:
+		buf.WriteString(f.str)
+	case intType:
+		var tmp [20]byte
+		buf.Write(strconv.AppendInt(tmp[:0], f.num, 10))
+	case boolType:
+		buf.WriteString(strconv.FormatBool(f.num != 0))
+	case anyType:
+		fmt.Fprint(buf, f.any)
+	}
+}
+
+func writeFieldJSON(buf *bytes.Buffer, f Field) {
+	switch f.ftype {
+	case stringType:
+		buf.WriteByte('"')
+		writeJSONEscaped(buf, f.str)
+		buf.WriteByte('"')
+	case intType:
+		var tmp [20]byte
+		buf.Write(strconv.AppendInt(tmp[:0], f.num, 10))
+	case boolType:
+		buf.WriteString(strconv.FormatBool(f.num != 0))
+	case anyType:
+		anyBuf := getBuf()
+		fmt.Fprint(anyBuf, f.any)
+
+		buf.WriteByte('"')
+		writeJSONEscapedBytes(buf, anyBuf.Bytes())
+		buf.WriteByte('"')
+
+		putBuf(anyBuf)
+	}
+}
+
+const _hexDigits = "0123456789abcdef"
+
+// writeJSONEscapedByte writes c as a single JSON string-literal byte,
+// escaping the quote/backslash characters and every control byte in
+// U+0000-U+001F per RFC 8259 so the result is always valid JSON, even
+// for fields built from free-form input (e.g. Err(err)).
+func writeJSONEscapedByte(buf *bytes.Buffer, c byte) {
+	switch c {
+	case '"':
+		buf.WriteString(`\"`)
+	case '\\':
+		buf.WriteString(`\\`)
+	case '\n':
+		buf.WriteString(`\n`)
+	case '\r':
+		buf.WriteString(`\r`)
+	case '\t':
+		buf.WriteString(`\t`)
+	case '\b':
+		buf.WriteString(`\b`)
+	case '\f':
+		buf.WriteString(`\f`)
+	default:
+		if c < 0x20 {
+			buf.WriteString(`\u00`)
+			buf.WriteByte(_hexDigits[c>>4])
+			buf.WriteByte(_hexDigits[c&0xF])
+
+			return
+		}
+
+		buf.WriteByte(c)
+	}
+}
+
+func writeJSONEscaped(buf *bytes.Buffer, s string) {
+	for i := 0; i < len(s); i++ {
+		writeJSONEscapedByte(buf, s[i])
+	}
+}
+
+func writeJSONEscapedBytes(buf *bytes.Buffer, b []byte) {
+	for _, c := range b {
+		writeJSONEscapedByte(buf, c)
+	}
+}
@@ -0,0 +1,77 @@
+package lgr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/WLM1ke/poptimizer/data/pkg/lgr"
+)
+
+func TestJSONEncoderStructuredEscapesControlBytes(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(&buf), lgr.JSONEncoder())
+
+	logger.Info("hello", lgr.String("k", "a\rb"))
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("JSONEncoder produced invalid JSON: %v\noutput: %s", err, buf.String())
+	}
+
+	if decoded["msg"] != "hello" {
+		t.Errorf(`decoded["msg"] = %v, want "hello"`, decoded["msg"])
+	}
+
+	if decoded["k"] != "a\rb" {
+		t.Errorf(`decoded["k"] = %q, want %q`, decoded["k"], "a\rb")
+	}
+}
+
+func TestJSONEncoderEscapesAnyField(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(&buf), lgr.JSONEncoder())
+
+	logger.Info("hello", lgr.Any("k", "a\"b\nc"))
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Any field produced invalid JSON: %v\noutput: %s", err, buf.String())
+	}
+
+	if decoded["k"] != "a\"b\nc" {
+		t.Errorf(`decoded["k"] = %q, want %q`, decoded["k"], "a\"b\nc")
+	}
+}
+
+func TestJSONEncoderAppliesToPrintfCalls(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(&buf), lgr.JSONEncoder())
+
+	logger.Infof("hello %s", "world")
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Infof under JSONEncoder produced invalid JSON: %v\noutput: %s", err, buf.String())
+	}
+
+	if decoded["msg"] != "hello world" {
+		t.Errorf(`decoded["msg"] = %v, want "hello world"`, decoded["msg"])
+	}
+}
+
+func TestConsoleEncoderIsDefaultForPrintfCalls(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(&buf))
+
+	logger.Infof("hello %s", "world")
+
+	if got := buf.String(); !strings.Contains(got, "test: hello world") {
+		t.Errorf("console output = %q, want it to contain %q", got, "test: hello world")
+	}
+}
@@ -0,0 +1,60 @@
+package lgr
+
+// fieldType discriminates the value stored in a Field, letting encoders
+// render it without a type switch on interface{}.
+type fieldType uint8
+
+const (
+	stringType fieldType = iota
+	intType
+	boolType
+	anyType
+)
+
+// Field is a typed key-value pair attached to a structured log entry via
+// Logger.Info/Logger.Warn. Build one with String, Int, Err, Any or Bool
+// rather than the zero value.
+type Field struct {
+	Key   string
+	ftype fieldType
+	str   string
+	num   int64
+	any   interface{}
+}
+
+// String builds a string-valued Field.
+func String(key, val string) Field {
+	return Field{Key: key, ftype: stringType, str: val}
+}
+
+// Int builds an int-valued Field.
+func Int(key string, val int) Field {
+	return Field{Key: key, ftype: intType, num: int64(val)}
+}
+
+// Bool builds a bool-valued Field.
+func Bool(key string, val bool) Field {
+	n := int64(0)
+	if val {
+		n = 1
+	}
+
+	return Field{Key: key, ftype: boolType, num: n}
+}
+
+// Err builds a Field named "error" from err's message, or an empty string
+// Field if err is nil.
+func Err(err error) Field {
+	if err == nil {
+		return Field{Key: "error", ftype: stringType}
+	}
+
+	return Field{Key: "error", ftype: stringType, str: err.Error()}
+}
+
+// Any builds a Field from an arbitrary value, falling back to fmt's
+// default formatting when it is encoded. Prefer the typed constructors on
+// the hot path; Any allocates.
+func Any(key string, val interface{}) Field {
+	return Field{Key: key, ftype: anyType, any: val}
+}
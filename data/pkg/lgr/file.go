@@ -0,0 +1,265 @@
+package lgr
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileOption configures a file sink built by FileWriter.
+type FileOption func(*File)
+
+// MaxSizeMB rotates the file once it exceeds sizeMB megabytes. 0 (the
+// default) disables size-based rotation.
+func MaxSizeMB(sizeMB int) FileOption {
+	return func(f *File) {
+		f.maxSizeB = int64(sizeMB) * 1024 * 1024
+	}
+}
+
+// MaxAgeDays removes rotated backups older than days. 0 (the default)
+// keeps backups forever.
+func MaxAgeDays(days int) FileOption {
+	return func(f *File) {
+		f.maxAgeDays = days
+	}
+}
+
+// MaxBackups keeps at most n rotated backups, removing the oldest first.
+// 0 (the default) keeps all of them.
+func MaxBackups(n int) FileOption {
+	return func(f *File) {
+		f.maxBackups = n
+	}
+}
+
+// LocalTime timestamps rotated backup file names using local time
+// instead of the default UTC.
+func LocalTime() FileOption {
+	return func(f *File) {
+		f.localTime = true
+	}
+}
+
+// Compress gzips rotated backups in a background goroutine instead of
+// leaving them as plain text.
+func Compress() FileOption {
+	return func(f *File) {
+		f.compress = true
+	}
+}
+
+// File is a rotating file sink, lumberjack-style: once it would pass
+// MaxSizeMB the current file is renamed aside and a fresh one opened in
+// its place. Build one with FileWriter.
+type File struct {
+	path       string
+	maxSizeB   int64
+	maxAgeDays int
+	maxBackups int
+	localTime  bool
+	compress   bool
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// FileWriter opens (or creates) path and returns a File ready to use as a
+// lgr.Writer(...) argument, so services can ship logs to disk without a
+// second dependency.
+func FileWriter(path string, opts ...FileOption) (*File, error) {
+	f := &File{path: path}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *File) open() error {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("lgr: open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("lgr: stat log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+
+	return nil
+}
+
+// Write appends b to the current file, rotating first if it would push
+// the file past MaxSizeMB. Rotation happens under f.mu so no log line is
+// ever dropped mid-write.
+func (f *File) Write(b []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxSizeB > 0 && f.size+int64(len(b)) > f.maxSizeB {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(b)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// rotate is called with f.mu held.
+func (f *File) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("lgr: close log file before rotation: %w", err)
+	}
+
+	backup := f.backupName()
+
+	if err := os.Rename(f.path, backup); err != nil {
+		return fmt.Errorf("lgr: rotate log file: %w", err)
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	if f.compress {
+		go compressAndRemove(backup)
+	}
+
+	go f.prune()
+
+	return nil
+}
+
+func (f *File) backupName() string {
+	now := time.Now()
+	if !f.localTime {
+		now = now.UTC()
+	}
+
+	ext := filepath.Ext(f.path)
+	base := strings.TrimSuffix(f.path, ext)
+
+	return fmt.Sprintf("%s-%s%s", base, now.Format("2006-01-02T15-04-05.000"), ext)
+}
+
+// compressAndRemove gzips path in the background and removes the
+// uncompressed backup, keeping rotation itself off the logging hot path.
+func compressAndRemove(path string) {
+	src, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return
+	}
+	defer dst.Close()
+
+	gw := gzip.NewWriter(dst)
+
+	if _, err := io.Copy(gw, src); err != nil {
+		_ = gw.Close()
+
+		return
+	}
+
+	if err := gw.Close(); err != nil {
+		return
+	}
+
+	_ = os.Remove(path)
+}
+
+// prune removes backups older than MaxAgeDays and, beyond that, anything
+// past MaxBackups. It runs in the background after each rotation so the
+// write path never blocks on a directory scan.
+func (f *File) prune() {
+	if f.maxBackups <= 0 && f.maxAgeDays <= 0 {
+		return
+	}
+
+	dir := filepath.Dir(f.path)
+	ext := filepath.Ext(f.path)
+	base := strings.TrimSuffix(filepath.Base(f.path), ext)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	var backups []string
+
+	for _, e := range entries {
+		name := e.Name()
+		if name == filepath.Base(f.path) || !strings.HasPrefix(name, base+"-") {
+			continue
+		}
+
+		backups = append(backups, filepath.Join(dir, name))
+	}
+
+	sort.Strings(backups) // timestamped names sort chronologically
+
+	if f.maxAgeDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -f.maxAgeDays)
+
+		kept := backups[:0]
+
+		for _, b := range backups {
+			if info, err := os.Stat(b); err == nil && info.ModTime().Before(cutoff) {
+				_ = os.Remove(b)
+
+				continue
+			}
+
+			kept = append(kept, b)
+		}
+
+		backups = kept
+	}
+
+	if f.maxBackups > 0 && len(backups) > f.maxBackups {
+		for _, b := range backups[:len(backups)-f.maxBackups] {
+			_ = os.Remove(b)
+		}
+	}
+}
+
+// Sync flushes buffered data to disk.
+func (f *File) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Sync()
+}
+
+// Close closes the current file.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Close()
+}
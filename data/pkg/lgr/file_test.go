@@ -0,0 +1,92 @@
+package lgr_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WLM1ke/poptimizer/data/pkg/lgr"
+)
+
+func TestFileWriterRotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	fw, err := lgr.FileWriter(path, lgr.MaxSizeMB(1))
+	if err != nil {
+		t.Fatalf("FileWriter: %v", err)
+	}
+	defer fw.Close()
+
+	if _, err := fw.Write([]byte("first line\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	big := make([]byte, 2*1024*1024)
+	for i := range big {
+		big[i] = 'x'
+	}
+
+	if _, err := fw.Write(big); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+
+	if len(entries) < 2 {
+		t.Fatalf("expected a rotated backup alongside app.log once MaxSizeMB was exceeded, got %v", entries)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	if len(data) != len(big) {
+		t.Errorf("current file has %d bytes, want the post-rotation write (%d bytes)", len(data), len(big))
+	}
+}
+
+func TestFileWriterCompressesBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	fw, err := lgr.FileWriter(path, lgr.MaxSizeMB(1), lgr.Compress())
+	if err != nil {
+		t.Fatalf("FileWriter: %v", err)
+	}
+	defer fw.Close()
+
+	if _, err := fw.Write([]byte("first line\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	big := make([]byte, 2*1024*1024)
+	if _, err := fw.Write(big); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+
+	for time.Now().Before(deadline) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("ReadDir: %v", err)
+		}
+
+		for _, e := range entries {
+			if strings.HasSuffix(e.Name(), ".gz") {
+				return
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("expected a compressed (.gz) backup to appear after rotation")
+}
@@ -0,0 +1,116 @@
+package lgr
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// appendf writes a minimal printf-style rendering of format/args into buf,
+// handling %s/%d/%f/%t for the common concrete types without an extra
+// fmt.Sprintf allocation; any other single-letter verb falls back to
+// fmt, which does allocate. Only the one byte after '%' is read as the
+// verb: flags, width and precision (e.g. %5d or %.2f) are not parsed and
+// do not render as they would with fmt.
+func appendf(buf *bytes.Buffer, format string, args ...interface{}) {
+	argIdx := 0
+
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+
+		if c != '%' || i+1 >= len(format) {
+			buf.WriteByte(c)
+
+			continue
+		}
+
+		i++
+		verb := format[i]
+
+		if verb == '%' {
+			buf.WriteByte('%')
+
+			continue
+		}
+
+		if argIdx >= len(args) {
+			buf.WriteByte('%')
+			buf.WriteByte(verb)
+
+			continue
+		}
+
+		appendArg(buf, verb, args[argIdx])
+		argIdx++
+	}
+}
+
+func appendArg(buf *bytes.Buffer, verb byte, arg interface{}) {
+	switch verb {
+	case 's':
+		appendString(buf, arg)
+	case 'd':
+		appendInt(buf, arg)
+	case 'f':
+		appendFloat(buf, arg)
+	case 't':
+		appendBool(buf, arg)
+	default:
+		fmt.Fprintf(buf, "%"+string(verb), arg)
+	}
+}
+
+func appendString(buf *bytes.Buffer, arg interface{}) {
+	switch v := arg.(type) {
+	case string:
+		buf.WriteString(v)
+	case error:
+		buf.WriteString(v.Error())
+	case fmt.Stringer:
+		buf.WriteString(v.String())
+	default:
+		fmt.Fprintf(buf, "%s", arg)
+	}
+}
+
+func appendInt(buf *bytes.Buffer, arg interface{}) {
+	var tmp [20]byte
+
+	switch v := arg.(type) {
+	case int:
+		buf.Write(strconv.AppendInt(tmp[:0], int64(v), 10))
+	case int32:
+		buf.Write(strconv.AppendInt(tmp[:0], int64(v), 10))
+	case int64:
+		buf.Write(strconv.AppendInt(tmp[:0], v, 10))
+	case uint:
+		buf.Write(strconv.AppendUint(tmp[:0], uint64(v), 10))
+	case uint64:
+		buf.Write(strconv.AppendUint(tmp[:0], v, 10))
+	default:
+		fmt.Fprintf(buf, "%d", arg)
+	}
+}
+
+func appendFloat(buf *bytes.Buffer, arg interface{}) {
+	var tmp [32]byte
+
+	switch v := arg.(type) {
+	case float64:
+		buf.Write(strconv.AppendFloat(tmp[:0], v, 'f', 6, 64))
+	case float32:
+		buf.Write(strconv.AppendFloat(tmp[:0], float64(v), 'f', 6, 32))
+	default:
+		fmt.Fprintf(buf, "%f", arg)
+	}
+}
+
+func appendBool(buf *bytes.Buffer, arg interface{}) {
+	if v, ok := arg.(bool); ok {
+		buf.WriteString(strconv.FormatBool(v))
+
+		return
+	}
+
+	fmt.Fprintf(buf, "%t", arg)
+}
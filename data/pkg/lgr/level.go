@@ -0,0 +1,33 @@
+package lgr
+
+// Level identifies the severity of a log record, in increasing order of
+// severity. The zero value is Debug.
+type Level uint8
+
+// Levels supported by Logger, ordered so MinLevel can compare them
+// directly.
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+
+	_levelCount
+)
+
+// String returns the level's upper-case name, as printed by both
+// encoders.
+func (l Level) String() string {
+	switch l {
+	case Debug:
+		return "DEBUG"
+	case Info:
+		return "INFO"
+	case Warn:
+		return "WARN"
+	case Error:
+		return "ERROR"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,50 @@
+package lgr_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/WLM1ke/poptimizer/data/pkg/lgr"
+)
+
+func TestMinLevelErrorIsReachable(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(&buf), lgr.MinLevel(lgr.Error))
+
+	logger.Debugf("debug %s", "x")
+	logger.Infof("info %s", "x")
+	logger.Warnf("warn %s", "x")
+	logger.Errorf("error %s", "x")
+
+	got := buf.String()
+	if strings.Contains(got, "debug") || strings.Contains(got, "info") || strings.Contains(got, "warn") {
+		t.Errorf("MinLevel(Error) let a lower-level record through: %q", got)
+	}
+
+	if !strings.Contains(got, "error x") {
+		t.Errorf("MinLevel(Error) suppressed Errorf, want it to log: %q", got)
+	}
+}
+
+func TestLevelWriterRoutesErrorsSeparately(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	logger := lgr.WithOptions(
+		lgr.Name("test"),
+		lgr.Writer(&out),
+		lgr.LevelWriter(lgr.Error, &errOut),
+	)
+
+	logger.Infof("info %s", "x")
+	logger.Errorf("error %s", "x")
+
+	if strings.Contains(out.String(), "error") {
+		t.Errorf("default writer got an Error record: %q", out.String())
+	}
+
+	if !strings.Contains(errOut.String(), "error x") {
+		t.Errorf("error writer = %q, want it to contain the Errorf record", errOut.String())
+	}
+}
@@ -0,0 +1,71 @@
+package lgr
+
+import (
+	"io"
+	"time"
+)
+
+// Name sets the logger's name, printed after the level in every record.
+func Name(name string) Option {
+	return func(l *Logger) {
+		l.name = name
+	}
+}
+
+// Writer sets the destination for log records. Defaults to os.Stdout.
+func Writer(w io.Writer) Option {
+	return func(l *Logger) {
+		l.out = w
+	}
+}
+
+// TimeWithSeconds truncates the logged timestamp to second precision,
+// dropping the default millisecond fraction.
+func TimeWithSeconds() Option {
+	return func(l *Logger) {
+		l.timeLayout = _withSecLayout
+	}
+}
+
+// ConsoleEncoder renders records as human-readable text. It is the
+// default encoder used by WithOptions.
+func ConsoleEncoder() Option {
+	return func(l *Logger) {
+		l.encoder = consoleEncoder{}
+	}
+}
+
+// JSONEncoder renders records as newline-delimited JSON objects, suitable
+// for ingestion by tools such as Loki or ELK that filter on fields rather
+// than regexes.
+func JSONEncoder() Option {
+	return func(l *Logger) {
+		l.encoder = jsonEncoder{}
+	}
+}
+
+// MinLevel suppresses records below level.
+func MinLevel(level Level) Option {
+	return func(l *Logger) {
+		l.minLevel = level
+	}
+}
+
+// LevelWriter routes records at level to w instead of the logger's
+// default Writer, e.g. sending Error to stderr while Info stays on
+// stdout.
+func LevelWriter(level Level, w io.Writer) Option {
+	return func(l *Logger) {
+		l.levelOut[level] = w
+	}
+}
+
+// Sampler keeps the first `first` calls that share a format string
+// within each interval, then logs every thereafter-th call after that.
+// It only applies to the printf-style Debugf/Infof/Warnf/Errorf path;
+// structured Info/Warn calls are never sampled.
+func Sampler(first, thereafter int, interval time.Duration) Option {
+	return func(l *Logger) {
+		l.sampler = newSampler(first, thereafter, interval)
+	}
+}
@@ -0,0 +1,302 @@
+package lgr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// OTLPOption configures an OTLP sink built by OTLPWriter.
+type OTLPOption func(*OTLP)
+
+// OTLPBatchSize caps how many records are batched into a single export
+// request. The default is 512.
+func OTLPBatchSize(n int) OTLPOption {
+	return func(o *OTLP) {
+		o.batchSize = n
+	}
+}
+
+// OTLPFlushInterval bounds how long a partial batch waits before being
+// exported anyway. The default is 5 seconds.
+func OTLPFlushInterval(d time.Duration) OTLPOption {
+	return func(o *OTLP) {
+		o.flushInterval = d
+	}
+}
+
+// OTLPHTTPClient overrides the *http.Client used to export batches,
+// e.g. to add auth headers via a custom RoundTripper.
+func OTLPHTTPClient(client *http.Client) OTLPOption {
+	return func(o *OTLP) {
+		o.client = client
+	}
+}
+
+// OTLPOnDecodeError is called, off the caller's goroutine, whenever a
+// written line can't be decoded as a JSON log record - for example
+// because the Logger is still using ConsoleEncoder instead of
+// JSONEncoder. Without it such lines are dropped with no diagnostic.
+func OTLPOnDecodeError(fn func(line []byte, err error)) OTLPOption {
+	return func(o *OTLP) {
+		o.onDecodeError = fn
+	}
+}
+
+// OTLP batches log records and exports them as OTLP logs over HTTP/JSON,
+// the OTLP transport that needs no generated protobuf/gRPC client. Build
+// one with OTLPWriter and use it as a lgr.Writer(...) argument together
+// with lgr.JSONEncoder(), since OTLP decodes each written line as JSON to
+// recover its level, message and fields.
+type OTLP struct {
+	endpoint      string
+	client        *http.Client
+	batchSize     int
+	flushInterval time.Duration
+	onDecodeError func(line []byte, err error)
+
+	queue   chan []byte
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// OTLPWriter returns an OTLP sink posting batched ExportLogsServiceRequest
+// bodies to endpoint. Decoding each written line and exporting the batch
+// both happen on a background goroutine, not the caller's, so the
+// parallel Infof/Warnf loop exercised by BenchmarkLogger keeps its
+// current latency profile.
+func OTLPWriter(endpoint string, opts ...OTLPOption) *OTLP {
+	o := &OTLP{
+		endpoint:      endpoint,
+		client:        http.DefaultClient,
+		batchSize:     512,
+		flushInterval: 5 * time.Second,
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	o.queue = make(chan []byte, o.batchSize*2)
+
+	go o.run()
+
+	return o
+}
+
+// Write copies b and enqueues it for export, returning immediately: JSON
+// decoding of b and the HTTP export both happen on the background
+// goroutine started by OTLPWriter, so the caller's goroutine never pays
+// for either. A full queue drops the line silently: unlike AsyncWriter,
+// OTLP keeps no drop counter and calls no drop callback.
+func (o *OTLP) Write(b []byte) (int, error) {
+	line := make([]byte, len(b))
+	copy(line, b)
+
+	select {
+	case o.queue <- line:
+	default:
+	}
+
+	return len(b), nil
+}
+
+func (o *OTLP) run() {
+	defer close(o.stopped)
+
+	ticker := time.NewTicker(o.flushInterval)
+	defer ticker.Stop()
+
+	var batch []otlpLogRecord
+
+	for {
+		select {
+		case line := <-o.queue:
+			batch = o.appendDecoded(batch, line)
+
+			if len(batch) >= o.batchSize {
+				o.export(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				o.export(batch)
+				batch = nil
+			}
+		case <-o.done:
+			o.drain(batch)
+
+			return
+		}
+	}
+}
+
+func (o *OTLP) appendDecoded(batch []otlpLogRecord, line []byte) []otlpLogRecord {
+	rec, err := decodeOTLPRecord(line)
+	if err != nil {
+		if o.onDecodeError != nil {
+			o.onDecodeError(line, err)
+		}
+
+		return batch
+	}
+
+	return append(batch, rec)
+}
+
+func (o *OTLP) drain(batch []otlpLogRecord) {
+	for {
+		select {
+		case line := <-o.queue:
+			batch = o.appendDecoded(batch, line)
+		default:
+			if len(batch) > 0 {
+				o.export(batch)
+			}
+
+			return
+		}
+	}
+}
+
+func (o *OTLP) export(batch []otlpLogRecord) {
+	body, err := json.Marshal(exportLogsServiceRequest{
+		ResourceLogs: []otlpResourceLogs{{
+			ScopeLogs: []otlpScopeLogs{{LogRecords: batch}},
+		}},
+	})
+	if err != nil {
+		return
+	}
+
+	resp, err := o.client.Post(o.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	_ = resp.Body.Close()
+}
+
+// Close flushes any pending batch and stops the background exporter.
+func (o *OTLP) Close() error {
+	close(o.done)
+	<-o.stopped
+
+	return nil
+}
+
+// otlpLogRecord, otlpScopeLogs, otlpResourceLogs and
+// exportLogsServiceRequest mirror the shapes of the OpenTelemetry Logs
+// Data Model's JSON encoding closely enough for any OTLP-compatible
+// backend (Tempo, Jaeger, Grafana Cloud) to ingest them directly.
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue,omitempty"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	SeverityNumber int            `json:"severityNumber"`
+	SeverityText   string         `json:"severityText"`
+	Body           otlpAnyValue   `json:"body"`
+	Attributes     []otlpKeyValue `json:"attributes,omitempty"`
+	TraceID        string         `json:"traceId,omitempty"`
+	SpanID         string         `json:"spanId,omitempty"`
+}
+
+type otlpScopeLogs struct {
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpResourceLogs struct {
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type exportLogsServiceRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+// decodeOTLPRecord parses one JSON line produced by jsonEncoder into an
+// OTLP log record, mapping severity per the OpenTelemetry Logs Data
+// Model (Debug->5, Info->9, Warn->13, Error->17) and pulling trace_id/
+// span_id fields - as attached by a context-bound Logger.With - into
+// OTLP's dedicated TraceId/SpanId columns instead of leaving them as
+// regular attributes.
+func decodeOTLPRecord(b []byte) (otlpLogRecord, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return otlpLogRecord{}, err
+	}
+
+	level, _ := raw["level"].(string)
+	msg, _ := raw["msg"].(string)
+	ts, _ := raw["ts"].(string)
+
+	rec := otlpLogRecord{
+		TimeUnixNano:   fmt.Sprintf("%d", parseOTLPTimestamp(ts).UnixNano()),
+		SeverityNumber: otlpSeverityNumber(level),
+		SeverityText:   level,
+		Body:           otlpAnyValue{StringValue: msg},
+	}
+
+	for k, v := range raw {
+		switch k {
+		case "ts", "level", "msg", "name":
+			continue
+		case "trace_id":
+			rec.TraceID, _ = v.(string)
+		case "span_id":
+			rec.SpanID, _ = v.(string)
+		default:
+			rec.Attributes = append(rec.Attributes, otlpKeyValue{
+				Key:   k,
+				Value: otlpAnyValue{StringValue: fmt.Sprint(v)},
+			})
+		}
+	}
+
+	return rec, nil
+}
+
+// otlpTimeLayouts lists every timeLayout a Logger can be built with (see
+// _defaultTimeLayout and _withSecLayout in lgr.go), tried in order since
+// the wire format carries no indication of which one produced "ts".
+var otlpTimeLayouts = []string{_defaultTimeLayout, _withSecLayout}
+
+// parseOTLPTimestamp recovers the event's own time from its "ts" field so
+// TimeUnixNano reflects when the record was logged, not when it was
+// decoded - the two can drift once decoding queues up behind a slow
+// export (see OTLPWriter's doc comment). It falls back to the current
+// time only if ts is empty or doesn't match a known layout.
+func parseOTLPTimestamp(ts string) time.Time {
+	for _, layout := range otlpTimeLayouts {
+		if t, err := time.ParseInLocation(layout, ts, time.Local); err == nil {
+			return t
+		}
+	}
+
+	return time.Now()
+}
+
+func otlpSeverityNumber(level string) int {
+	switch level {
+	case "DEBUG":
+		return 5
+	case "INFO":
+		return 9
+	case "WARN":
+		return 13
+	case "ERROR":
+		return 17
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,192 @@
+package lgr_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/WLM1ke/poptimizer/data/pkg/lgr"
+)
+
+func TestOTLPWriterExportsBatchedRecords(t *testing.T) {
+	var mu sync.Mutex
+
+	var bodies [][]byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	otlp := lgr.OTLPWriter(server.URL, lgr.OTLPFlushInterval(20*time.Millisecond))
+
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(otlp), lgr.JSONEncoder())
+	logger.Warnf("disk nearly full")
+
+	if err := otlp.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(bodies) == 0 {
+		t.Fatal("OTLPWriter never exported a batch")
+	}
+
+	var req struct {
+		ResourceLogs []struct {
+			ScopeLogs []struct {
+				LogRecords []struct {
+					SeverityNumber int `json:"severityNumber"`
+					Body           struct {
+						StringValue string `json:"stringValue"`
+					} `json:"body"`
+				} `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+
+	if err := json.Unmarshal(bodies[0], &req); err != nil {
+		t.Fatalf("exported body is not valid OTLP/JSON: %v\nbody: %s", err, bodies[0])
+	}
+
+	records := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+	if len(records) != 1 {
+		t.Fatalf("got %d log records, want 1", len(records))
+	}
+
+	if records[0].SeverityNumber != 13 {
+		t.Errorf("SeverityNumber = %d, want 13 (Warn)", records[0].SeverityNumber)
+	}
+
+	if records[0].Body.StringValue != "disk nearly full" {
+		t.Errorf("Body.StringValue = %q, want %q", records[0].Body.StringValue, "disk nearly full")
+	}
+}
+
+func TestOTLPWriterTimestampSurvivesExportStall(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		bodies  [][]byte
+		reqSeen int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		reqSeen++
+		n := reqSeen
+		mu.Unlock()
+
+		// Stall the first export so the second record sits queued behind
+		// it and gets decoded well after it was actually logged.
+		if n == 1 {
+			time.Sleep(300 * time.Millisecond)
+		}
+
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	otlp := lgr.OTLPWriter(server.URL, lgr.OTLPBatchSize(1), lgr.OTLPFlushInterval(time.Hour))
+
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(otlp), lgr.JSONEncoder())
+
+	logger.Warnf("first")
+
+	// Give the run loop time to dequeue "first" and start (and block on)
+	// its export before "second" is written and queued behind it.
+	time.Sleep(20 * time.Millisecond)
+
+	wantTime := time.Now()
+	logger.Warnf("second")
+
+	if err := otlp.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(bodies) != 2 {
+		t.Fatalf("got %d exported batches, want 2", len(bodies))
+	}
+
+	var req struct {
+		ResourceLogs []struct {
+			ScopeLogs []struct {
+				LogRecords []struct {
+					TimeUnixNano string `json:"timeUnixNano"`
+				} `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+
+	if err := json.Unmarshal(bodies[1], &req); err != nil {
+		t.Fatalf("second exported body is not valid OTLP/JSON: %v\nbody: %s", err, bodies[1])
+	}
+
+	var gotNano int64
+	if _, err := fmt.Sscanf(req.ResourceLogs[0].ScopeLogs[0].LogRecords[0].TimeUnixNano, "%d", &gotNano); err != nil {
+		t.Fatalf("timeUnixNano %q is not an integer: %v", req.ResourceLogs[0].ScopeLogs[0].LogRecords[0].TimeUnixNano, err)
+	}
+
+	got := time.Unix(0, gotNano)
+
+	if drift := got.Sub(wantTime); drift < -time.Second || drift > time.Second {
+		t.Errorf("timeUnixNano drifted from the logged event by %v (export stall was 300ms); got %v, logged at %v", drift, got, wantTime)
+	}
+}
+
+func TestOTLPWriterReportsDecodeErrors(t *testing.T) {
+	var mu sync.Mutex
+
+	var decodeErrs int
+
+	otlp := lgr.OTLPWriter("http://127.0.0.1:0",
+		lgr.OTLPOnDecodeError(func(line []byte, err error) {
+			mu.Lock()
+			decodeErrs++
+			mu.Unlock()
+		}),
+	)
+	defer otlp.Close()
+
+	// ConsoleEncoder output is not JSON, so OTLP should flag it instead of
+	// silently dropping it.
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(otlp))
+	logger.Infof("not json")
+
+	deadline := time.Now().Add(2 * time.Second)
+
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		got := decodeErrs
+		mu.Unlock()
+
+		if got > 0 {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("OTLPOnDecodeError was never called for a non-JSON line")
+}
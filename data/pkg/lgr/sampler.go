@@ -0,0 +1,81 @@
+package lgr
+
+import (
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// sampler implements the zap/zerolog-style token sampling: the first N
+// calls sharing a format string within an interval are logged, then only
+// every thereafter-th call after that.
+type sampler struct {
+	first      uint64
+	thereafter uint64
+	interval   time.Duration
+
+	mu       sync.Mutex
+	counters map[uintptr]*sampleCounter
+}
+
+type sampleCounter struct {
+	windowStart int64
+	count       uint64
+}
+
+func newSampler(first, thereafter int, interval time.Duration) *sampler {
+	return &sampler{
+		first:      uint64(first),
+		thereafter: uint64(thereafter),
+		interval:   interval,
+		counters:   make(map[uintptr]*sampleCounter),
+	}
+}
+
+// allow reports whether the call identified by format should be logged.
+// It keys the sampling window on format's underlying data pointer rather
+// than its contents, so repeated calls with the same format-string
+// literal never hash or compare bytes on the hot path.
+func (s *sampler) allow(format string) bool {
+	key := formatPtr(format)
+	now := time.Now().UnixNano()
+
+	s.mu.Lock()
+
+	c, ok := s.counters[key]
+	if !ok {
+		c = &sampleCounter{windowStart: now}
+		s.counters[key] = c
+	}
+
+	if s.interval > 0 && now-c.windowStart >= int64(s.interval) {
+		c.windowStart = now
+		c.count = 0
+	}
+
+	c.count++
+	n := c.count
+
+	s.mu.Unlock()
+
+	if n <= s.first {
+		return true
+	}
+
+	if s.thereafter == 0 {
+		return false
+	}
+
+	return (n-s.first)%s.thereafter == 0
+}
+
+// stringHeader mirrors the runtime's string layout, letting formatPtr
+// read the data pointer without allocating.
+type stringHeader struct {
+	Data uintptr
+	Len  int
+}
+
+func formatPtr(s string) uintptr {
+	return (*stringHeader)(unsafe.Pointer(&s)).Data
+}
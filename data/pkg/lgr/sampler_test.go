@@ -0,0 +1,79 @@
+package lgr_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WLM1ke/poptimizer/data/pkg/lgr"
+)
+
+func TestSamplerKeepsFirstThenEveryThereafterTh(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(&buf), lgr.Sampler(2, 3, time.Hour))
+
+	for i := 0; i < 9; i++ {
+		logger.Infof("tick\n")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	// Calls 1-2 pass (first=2), then only every 3rd call after that
+	// passes: call 5 (2+3) and call 8 (2+6) - 4 lines total.
+	if want := 4; len(lines) != want {
+		t.Fatalf("got %d logged lines, want %d: %q", len(lines), want, buf.String())
+	}
+}
+
+func TestSamplerResetsWindowAfterInterval(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(&buf), lgr.Sampler(1, 0, 20*time.Millisecond))
+
+	logger.Infof("tick\n")
+	logger.Infof("tick\n") // suppressed: thereafter=0 keeps only the first per window
+
+	time.Sleep(40 * time.Millisecond)
+
+	logger.Infof("tick\n") // new window: first call passes again
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if want := 2; len(lines) != want {
+		t.Fatalf("got %d logged lines, want %d across two windows: %q", len(lines), want, buf.String())
+	}
+}
+
+func TestSamplerCountsEachFormatStringSeparately(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(&buf), lgr.Sampler(1, 0, time.Hour))
+
+	logger.Infof("alpha\n")
+	logger.Infof("alpha\n") // suppressed, same format string
+	logger.Infof("beta\n")  // distinct format string, its own counter
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if want := 2; len(lines) != want {
+		t.Fatalf("got %d logged lines, want %d: %q", len(lines), want, buf.String())
+	}
+
+	if !strings.Contains(lines[0], "alpha") || !strings.Contains(lines[1], "beta") {
+		t.Errorf("expected one alpha line then one beta line, got %q", lines)
+	}
+}
+
+func TestSamplerOnlyAppliesToPrintfCalls(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := lgr.WithOptions(lgr.Name("test"), lgr.Writer(&buf), lgr.Sampler(1, 0, time.Hour))
+
+	logger.Info("structured")
+	logger.Info("structured")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if want := 2; len(lines) != want {
+		t.Fatalf("Sampler suppressed a structured Info call; got %d lines, want %d: %q", len(lines), want, buf.String())
+	}
+}